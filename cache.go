@@ -0,0 +1,78 @@
+package lrclib
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CachedResponse is a minimal, re-playable snapshot of an HTTP response body
+// that a Cache implementation stores and returns.
+type CachedResponse struct {
+	StatusCode int
+	Body       []byte
+}
+
+// Cache lets callers plug in their own storage for GET responses (search and
+// lookup calls). Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(req *http.Request) (*CachedResponse, bool)
+	Set(req *http.Request, resp *CachedResponse)
+}
+
+type memoryCacheEntry struct {
+	resp    *CachedResponse
+	expires time.Time
+}
+
+// MemoryCache is the built-in in-memory Cache implementation. Entries expire
+// after LyricsTimeToLive; an expired entry is removed lazily when Get next
+// looks up its key, or replaced when Set stores that key again.
+type MemoryCache struct {
+	// LyricsTimeToLive controls how long a cached response stays valid.
+	LyricsTimeToLive time.Duration
+
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+}
+
+// NewMemoryCache returns a MemoryCache that keeps entries for ttl.
+func NewMemoryCache(ttl time.Duration) *MemoryCache {
+	return &MemoryCache{
+		LyricsTimeToLive: ttl,
+		entries:          make(map[string]memoryCacheEntry),
+	}
+}
+
+func cacheKey(req *http.Request) string {
+	return req.Method + " " + req.URL.String()
+}
+
+func (c *MemoryCache) Get(req *http.Request) (*CachedResponse, bool) {
+	key := cacheKey(req)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.resp, true
+}
+
+func (c *MemoryCache) Set(req *http.Request, resp *CachedResponse) {
+	key := cacheKey(req)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]memoryCacheEntry)
+	}
+	c.entries[key] = memoryCacheEntry{resp: resp, expires: time.Now().Add(c.LyricsTimeToLive)}
+}
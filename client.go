@@ -0,0 +1,294 @@
+package lrclib
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultBaseURL    = "https://lrclib.net/api"
+	defaultUserAgent  = "lrclib-go (+https://github.com/oq-x/lrclib-go)"
+	defaultMaxRetries = 3
+	defaultBackoff    = 500 * time.Millisecond
+)
+
+// Client is an lrclib.net API client. The zero value is not usable; create
+// one with NewClient.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	userAgent  string
+	cache      Cache
+	maxRetries int
+}
+
+// ClientOption configures a Client returned by NewClient.
+type ClientOption func(*Client)
+
+// WithHTTPClient overrides the *http.Client used for requests.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithBaseURL overrides the API base URL, e.g. to point at a mirror or a
+// test server.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) { c.baseURL = baseURL }
+}
+
+// WithUserAgent overrides the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) { c.userAgent = userAgent }
+}
+
+// WithCache enables response caching for GET requests (SearchSong, GetSong,
+// GetSongByID) using cache.
+func WithCache(cache Cache) ClientOption {
+	return func(c *Client) { c.cache = cache }
+}
+
+// WithMaxRetries overrides how many times a request is retried on 5xx or 429
+// responses before giving up. Defaults to 3.
+func WithMaxRetries(maxRetries int) ClientOption {
+	return func(c *Client) { c.maxRetries = maxRetries }
+}
+
+// NewClient returns a Client ready to use, applying opts on top of the
+// defaults (no caching, 3 retries, lrclib.net as the base URL).
+func NewClient(opts ...ClientOption) *Client {
+	c := &Client{
+		httpClient: http.DefaultClient,
+		baseURL:    defaultBaseURL,
+		userAgent:  defaultUserAgent,
+		maxRetries: defaultMaxRetries,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+var defaultClient = NewClient()
+
+// do sends req, retrying on 5xx and 429 responses and honoring a Retry-After
+// header when present. It returns the final response body already drained
+// into memory, since every caller in this package needs to either decode it
+// or cache it.
+func (c *Client) do(req *http.Request) ([]byte, int, error) {
+	backoff := defaultBackoff
+
+	for attempt := 0; ; attempt++ {
+		res, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		retryable := res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+		if !retryable || attempt >= c.maxRetries {
+			return body, res.StatusCode, nil
+		}
+
+		wait := retryAfter(res.Header.Get("Retry-After"), backoff)
+		select {
+		case <-req.Context().Done():
+			return nil, 0, req.Context().Err()
+		case <-time.After(wait):
+		}
+
+		if req.GetBody != nil {
+			newBody, err := req.GetBody()
+			if err != nil {
+				return nil, 0, err
+			}
+			req.Body = newBody
+		}
+		backoff *= 2
+	}
+}
+
+// retryAfter parses a Retry-After header (seconds form) and falls back to
+// backoff when absent or malformed.
+func retryAfter(header string, backoff time.Duration) time.Duration {
+	if header == "" {
+		return backoff
+	}
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds < 0 {
+		return backoff
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// decodeError turns a non-2xx response into ErrNotFound, ErrRateLimited, or
+// a wrapped *PublishError, preferring the most specific one available.
+func decodeError(statusCode int, body []byte) error {
+	var payload PublishError
+	hasPayload := json.Unmarshal(body, &payload) == nil && payload.Message != ""
+
+	switch statusCode {
+	case http.StatusNotFound:
+		if hasPayload {
+			return fmt.Errorf("%w: %w", ErrNotFound, &payload)
+		}
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		if hasPayload {
+			return fmt.Errorf("%w: %w", ErrRateLimited, &payload)
+		}
+		return ErrRateLimited
+	default:
+		if hasPayload {
+			return &payload
+		}
+		return fmt.Errorf("lrclib: unexpected status %d", statusCode)
+	}
+}
+
+// get performs a cached, retrying GET request against path and decodes the
+// JSON response into v.
+func (c *Client) get(ctx context.Context, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	if c.cache != nil {
+		if cached, ok := c.cache.Get(req); ok {
+			if cached.StatusCode != http.StatusOK {
+				return decodeError(cached.StatusCode, cached.Body)
+			}
+			return json.Unmarshal(cached.Body, v)
+		}
+	}
+
+	body, statusCode, err := c.do(req)
+	if err != nil {
+		return err
+	}
+
+	if c.cache != nil && (statusCode == http.StatusOK || statusCode == http.StatusNotFound) {
+		c.cache.Set(req, &CachedResponse{StatusCode: statusCode, Body: body})
+	}
+
+	if statusCode != http.StatusOK {
+		return decodeError(statusCode, body)
+	}
+	return json.Unmarshal(body, v)
+}
+
+// SearchSong searches lrclib.net for lyrics matching the given query and/or
+// track metadata. Any of the fields may be empty.
+func (c *Client) SearchSong(ctx context.Context, query, trackName, artistName, albumName string) ([]SongData, error) {
+	path := fmt.Sprintf("/search?q=%s&track_name=%s&artist_name=%s&album_name=%s",
+		url.QueryEscape(query), url.QueryEscape(trackName), url.QueryEscape(artistName), url.QueryEscape(albumName))
+
+	var data []SongData
+	if err := c.get(ctx, path, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetSong looks up lyrics for an exact track. If cached is true, the
+// -cached variant of the endpoint is used, which never triggers a fresh
+// lookup on lrclib.net's side.
+func (c *Client) GetSong(ctx context.Context, trackName, artistName, albumName string, duration time.Duration, cached bool) (SongData, error) {
+	endpoint := "/get"
+	if cached {
+		endpoint = "/get-cached"
+	}
+	path := fmt.Sprintf("%s?track_name=%s&artist_name=%s&album_name=%s&duration=%d",
+		endpoint, url.QueryEscape(trackName), url.QueryEscape(artistName), url.QueryEscape(albumName), duration/time.Second)
+
+	var data SongData
+	if err := c.get(ctx, path, &data); err != nil {
+		return SongData{}, err
+	}
+	return data, nil
+}
+
+// GetSongByID looks up lyrics by their lrclib.net ID.
+func (c *Client) GetSongByID(ctx context.Context, id string) (SongData, error) {
+	var data SongData
+	if err := c.get(ctx, "/get/"+url.QueryEscape(id), &data); err != nil {
+		return SongData{}, err
+	}
+	return data, nil
+}
+
+// PublishSong solves a proof-of-work challenge and publishes song to
+// lrclib.net. Publish requests are never cached.
+func (c *Client) PublishSong(ctx context.Context, song SongData) error {
+	body, err := json.Marshal(song)
+	if err != nil {
+		return err
+	}
+
+	token, err := c.newPublishToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/publish", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("X-Publish-Token", token)
+
+	respBody, statusCode, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	if statusCode == http.StatusCreated {
+		return nil
+	}
+	return decodeError(statusCode, respBody)
+}
+
+// newPublishToken requests a proof-of-work challenge from lrclib.net, solves
+// it, and returns the resulting publish token.
+func (c *Client) newPublishToken(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/request-challenge", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	body, statusCode, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+	if statusCode != http.StatusOK {
+		return "", decodeError(statusCode, body)
+	}
+
+	var response struct {
+		Prefix string `json:"prefix"`
+		Target string `json:"target"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", err
+	}
+
+	nonce, err := SolveChallenge(ctx, response.Prefix, response.Target)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("{%s}:{%s}", response.Prefix, nonce), nil
+}
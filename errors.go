@@ -0,0 +1,26 @@
+package lrclib
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is returned when the API has no matching lyrics for a request.
+var ErrNotFound = errors.New("lrclib: song not found")
+
+// ErrRateLimited is returned when the API responds with 429 and retries have
+// been exhausted.
+var ErrRateLimited = errors.New("lrclib: rate limited")
+
+// PublishError is the structured error payload the API returns alongside
+// non-2xx responses, e.g. from /api/publish or /api/get. Callers can recover
+// it from a returned error with errors.As to inspect Code/Name/Message.
+type PublishError struct {
+	Code    int    `json:"code"`
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+func (e *PublishError) Error() string {
+	return fmt.Sprintf("%s (code %d): %s", e.Name, e.Code, e.Message)
+}
@@ -0,0 +1,236 @@
+package lrclib
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SyncedLyric is a single timestamped line of lyrics.
+type SyncedLyric struct {
+	At    time.Duration
+	Text  string
+	Index int
+}
+
+// WordTiming is a single word's timestamp within an enhanced (A2) LRC line.
+type WordTiming struct {
+	At   time.Duration
+	Word string
+}
+
+// LRCFile is the fully parsed representation of an LRC document: its ID3-style
+// metadata tags, one entry per synced line, and, for enhanced LRC, the
+// per-word timings for that same line (nil where a line has none).
+type LRCFile struct {
+	Metadata map[string]string
+	Lines    []SyncedLyric
+	Words    [][]WordTiming
+}
+
+var (
+	lineTagRe = regexp.MustCompile(`^\[(\d{1,3}):(\d{2}(?:\.\d{1,3})?)\]`)
+	metaTagRe = regexp.MustCompile(`^\[([A-Za-z]+):(.*)\]$`)
+	wordTagRe = regexp.MustCompile(`<(\d{1,3}):(\d{2}(?:\.\d{1,3})?)>`)
+)
+
+// metadataOrder lists the well-known ID3-style tags in the order players
+// conventionally write them; anything else is appended afterwards, sorted.
+var metadataOrder = []string{"ar", "ti", "al", "length", "offset"}
+
+func parseTimestamp(minutesPart, secondsPart string) (time.Duration, bool) {
+	minutes, err := strconv.ParseInt(minutesPart, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	seconds, err := strconv.ParseFloat(secondsPart, 64)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(minutes)*time.Minute + time.Duration(seconds*float64(time.Second)), true
+}
+
+// parseWordTimings extracts per-word <mm:ss.xx> tags from an enhanced LRC
+// line's text, returning nil if the line has none.
+func parseWordTimings(text string) []WordTiming {
+	matches := wordTagRe.FindAllStringSubmatchIndex(text, -1)
+	if matches == nil {
+		return nil
+	}
+
+	words := make([]WordTiming, 0, len(matches))
+	for i, m := range matches {
+		at, ok := parseTimestamp(text[m[2]:m[3]], text[m[4]:m[5]])
+		if !ok {
+			continue
+		}
+		end := len(text)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		word := strings.TrimSpace(text[m[1]:end])
+		words = append(words, WordTiming{At: at, Word: word})
+	}
+	return words
+}
+
+// ParseLRC parses an LRC (or enhanced/A2 LRC) document. Lines with multiple
+// leading timestamps are expanded into one SyncedLyric per timestamp, and
+// malformed lines are skipped rather than aborting the parse.
+func ParseLRC(str string) *LRCFile {
+	file := &LRCFile{Metadata: make(map[string]string)}
+
+	for _, rawLine := range strings.Split(str, "\n") {
+		line := strings.TrimRight(rawLine, "\r")
+		if line == "" {
+			continue
+		}
+
+		var stamps []time.Duration
+		rest := line
+		for {
+			m := lineTagRe.FindStringSubmatch(rest)
+			if m == nil {
+				break
+			}
+			at, ok := parseTimestamp(m[1], m[2])
+			if !ok {
+				break
+			}
+			stamps = append(stamps, at)
+			rest = rest[len(m[0]):]
+		}
+
+		if len(stamps) == 0 {
+			if m := metaTagRe.FindStringSubmatch(line); m != nil {
+				file.Metadata[strings.ToLower(m[1])] = strings.TrimSpace(m[2])
+			}
+			continue
+		}
+
+		text := strings.TrimPrefix(rest, " ")
+		words := parseWordTimings(text)
+		plainText := wordTagRe.ReplaceAllString(text, "")
+
+		for _, at := range stamps {
+			file.Lines = append(file.Lines, SyncedLyric{At: at, Text: plainText, Index: len(file.Lines)})
+			// Each stamp gets its own copy of words: applyOffset mutates
+			// file.Words in place, and stamps sharing one slice would have
+			// the offset applied to it once per stamp.
+			file.Words = append(file.Words, append([]WordTiming(nil), words...))
+		}
+	}
+
+	if raw, ok := file.Metadata["offset"]; ok {
+		if ms, err := strconv.ParseFloat(raw, 64); err == nil {
+			applyOffset(file, time.Duration(ms)*time.Millisecond)
+		}
+	}
+
+	return file
+}
+
+func applyOffset(file *LRCFile, offset time.Duration) {
+	if offset == 0 {
+		return
+	}
+	for i := range file.Lines {
+		file.Lines[i].At += offset
+	}
+	for _, words := range file.Words {
+		for i := range words {
+			words[i].At += offset
+		}
+	}
+}
+
+// ParseSyncedLyrics parses str and returns just its synced lines, discarding
+// metadata tags and word-level timing. Kept for callers that only need
+// line-level sync; new code should prefer ParseLRC.
+func ParseSyncedLyrics(str string) []SyncedLyric {
+	return ParseLRC(str).Lines
+}
+
+func formatTimestamp(at time.Duration) string {
+	// Round to centiseconds before splitting into minutes/seconds so that
+	// rounding up (e.g. 119.996s) carries into minutes instead of producing
+	// an out-of-range "60.00" seconds field.
+	centiseconds := (at + 5*time.Millisecond).Milliseconds() / 10
+	minutes := centiseconds / 6000
+	seconds := float64(centiseconds%6000) / 100
+	return fmt.Sprintf("%02d:%05.2f", minutes, seconds)
+}
+
+// FormatSyncedLyrics renders lines back into standard LRC line syntax, e.g.
+// "[01:07.32] some lyric".
+func FormatSyncedLyrics(lyrics []SyncedLyric) string {
+	lines := make([]string, len(lyrics))
+	for i, lyric := range lyrics {
+		lines[i] = fmt.Sprintf("[%s] %s", formatTimestamp(lyric.At), lyric.Text)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func writeMetadata(b *strings.Builder, metadata map[string]string) {
+	written := make(map[string]bool, len(metadataOrder))
+	for _, key := range metadataOrder {
+		if v, ok := metadata[key]; ok {
+			fmt.Fprintf(b, "[%s:%s]\n", key, v)
+			written[key] = true
+		}
+	}
+
+	extra := make([]string, 0, len(metadata)-len(written))
+	for key := range metadata {
+		if !written[key] {
+			extra = append(extra, key)
+		}
+	}
+	sort.Strings(extra)
+	for _, key := range extra {
+		fmt.Fprintf(b, "[%s:%s]\n", key, metadata[key])
+	}
+}
+
+// FormatLRC renders a full LRC document: metadata tags followed by the
+// synced lines.
+func FormatLRC(file *LRCFile) string {
+	var b strings.Builder
+	writeMetadata(&b, file.Metadata)
+	b.WriteString(FormatSyncedLyrics(file.Lines))
+	return b.String()
+}
+
+// EnhancedLRC renders file as enhanced (A2) LRC, emitting per-word
+// <mm:ss.xx> tags for any line that has Words, and falling back to a plain
+// line otherwise.
+func EnhancedLRC(file *LRCFile) string {
+	var b strings.Builder
+	writeMetadata(&b, file.Metadata)
+
+	for i, lyric := range file.Lines {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "[%s]", formatTimestamp(lyric.At))
+
+		var words []WordTiming
+		if i < len(file.Words) {
+			words = file.Words[i]
+		}
+		if len(words) == 0 {
+			b.WriteString(lyric.Text)
+			continue
+		}
+		for j, w := range words {
+			if j > 0 {
+				b.WriteByte(' ')
+			}
+			fmt.Fprintf(&b, "<%s>%s", formatTimestamp(w.At), w.Word)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,231 @@
+package lrclib
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// defaultDurationTolerance is how far apart, in seconds, two tracks'
+// durations can be before the duration penalty caps out, mirroring how
+// AccurateRip-style TOC lookups reject wrong-length matches.
+const defaultDurationTolerance = 2 * time.Second
+
+const (
+	trackWeight        = 0.45
+	artistWeight       = 0.35
+	albumWeight        = 0.20
+	textWeight         = 0.85
+	durationTermWeight = 0.15
+)
+
+var (
+	parenthesesRe = regexp.MustCompile(`\([^)]*\)`)
+	dashSuffixRe  = regexp.MustCompile(`\s+-\s+.*$`)
+	punctuationRe = regexp.MustCompile(`[^\p{L}\p{N} ]+`)
+)
+
+// normalizeForMatch lowercases s, strips common parentheticals like
+// "(Remastered 2011)" and dash suffixes like "- Live", removes punctuation,
+// and collapses whitespace.
+func normalizeForMatch(s string) string {
+	s = strings.ToLower(s)
+	s = parenthesesRe.ReplaceAllString(s, " ")
+	s = dashSuffixRe.ReplaceAllString(s, "")
+	s = punctuationRe.ReplaceAllString(s, " ")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 {
+		return len(rb)
+	}
+	if len(rb) == 0 {
+		return len(ra)
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(curr[j-1]+1, prev[j]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
+
+// jaroWinkler returns the Jaro-Winkler similarity of a and b, in [0, 1].
+func jaroWinkler(a, b string) float64 {
+	j := jaro(a, b)
+
+	ra, rb := []rune(a), []rune(b)
+	prefix := 0
+	for prefix < 4 && prefix < len(ra) && prefix < len(rb) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+	return j + float64(prefix)*0.1*(1-j)
+}
+
+func jaro(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	la, lb := len(ra), len(rb)
+	if la == 0 && lb == 0 {
+		return 1
+	}
+	if la == 0 || lb == 0 {
+		return 0
+	}
+
+	matchDistance := la
+	if lb > matchDistance {
+		matchDistance = lb
+	}
+	matchDistance = matchDistance/2 - 1
+	if matchDistance < 0 {
+		matchDistance = 0
+	}
+
+	aMatches := make([]bool, la)
+	bMatches := make([]bool, lb)
+	matches := 0
+
+	for i := 0; i < la; i++ {
+		start, end := i-matchDistance, i+matchDistance+1
+		if start < 0 {
+			start = 0
+		}
+		if end > lb {
+			end = lb
+		}
+		for j := start; j < end; j++ {
+			if bMatches[j] || ra[i] != rb[j] {
+				continue
+			}
+			aMatches[i] = true
+			bMatches[j] = true
+			matches++
+			break
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	k := 0
+	for i := 0; i < la; i++ {
+		if !aMatches[i] {
+			continue
+		}
+		for !bMatches[k] {
+			k++
+		}
+		if ra[i] != rb[k] {
+			transpositions++
+		}
+		k++
+	}
+	transpositions /= 2
+
+	m := float64(matches)
+	return (m/float64(la) + m/float64(lb) + (m-float64(transpositions))/m) / 3
+}
+
+// stringSimilarity combines normalized Levenshtein and Jaro-Winkler
+// similarity of a and b into a single score in [0, 1].
+func stringSimilarity(a, b string) float64 {
+	a, b = normalizeForMatch(a), normalizeForMatch(b)
+	if a == "" && b == "" {
+		return 1
+	}
+
+	maxLen := len([]rune(a))
+	if n := len([]rune(b)); n > maxLen {
+		maxLen = n
+	}
+	levenshteinSim := 1.0
+	if maxLen > 0 {
+		levenshteinSim = 1 - float64(levenshtein(a, b))/float64(maxLen)
+	}
+
+	return (levenshteinSim + jaroWinkler(a, b)) / 2
+}
+
+// durationScore scores how close two durations are, capping the penalty at
+// tolerance so a good text match isn't sunk by a slightly-off duration.
+func durationScore(a, b time.Duration, tolerance time.Duration) float64 {
+	if tolerance <= 0 {
+		if a == b {
+			return 1
+		}
+		return 0
+	}
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	score := 1 - float64(diff)/float64(tolerance)
+	if score < 0 {
+		return 0
+	}
+	return score
+}
+
+// BestMatch scores results against query and returns the best candidate
+// along with its score in [0, 1]. The bool return is false only when
+// results is empty, since callers need a threshold on the score (not just a
+// yes/no) to decide whether to trust the match.
+func BestMatch(results []SongData, query TrackQuery) (SongData, float64, bool) {
+	if len(results) == 0 {
+		return SongData{}, 0, false
+	}
+
+	best := results[0]
+	bestScore := -1.0
+
+	for _, candidate := range results {
+		weightSum := trackWeight + artistWeight
+		textScore := trackWeight*stringSimilarity(candidate.TrackName, query.TrackName) +
+			artistWeight*stringSimilarity(candidate.ArtistName, query.ArtistName)
+		if query.AlbumName != "" {
+			weightSum += albumWeight
+			textScore += albumWeight * stringSimilarity(candidate.AlbumName, query.AlbumName)
+		}
+		textScore /= weightSum
+
+		score := textScore
+		if query.Duration > 0 {
+			candidateDuration := time.Duration(candidate.Duration * float64(time.Second))
+			score = textWeight*textScore + durationTermWeight*durationScore(candidateDuration, query.Duration, defaultDurationTolerance)
+		}
+
+		if score > bestScore {
+			bestScore = score
+			best = candidate
+		}
+	}
+
+	return best, bestScore, true
+}
@@ -0,0 +1,154 @@
+package lrclib
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding"
+	"encoding/hex"
+	"errors"
+	"math"
+	"math/big"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// SolveChallenge searches for a nonce such that sha256(prefix+nonce) is
+// lexicographically at or below target (hex encoded), sharding the nonce
+// space across runtime.NumCPU() workers. Worker k tries nonces k, k+N, k+2N,
+// ... All workers stop as soon as one succeeds or ctx is canceled. The
+// nonce returned is the first one any worker finds, not necessarily the
+// smallest valid one.
+func SolveChallenge(ctx context.Context, prefix string, targetHex string) (string, error) {
+	target, err := hex.DecodeString(targetHex)
+	if err != nil {
+		return "", err
+	}
+
+	prefixState, hasPrefixState := marshalPrefixState(prefix)
+
+	workers := runtime.NumCPU()
+	if workers < 1 {
+		workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var found int32
+	result := make(chan string, 1)
+	var wg sync.WaitGroup
+
+	for k := 0; k < workers; k++ {
+		wg.Add(1)
+		go func(start int) {
+			defer wg.Done()
+			for nonce := start; ; nonce += workers {
+				if atomic.LoadInt32(&found) != 0 {
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+
+				hashed := hashChallenge(prefix, prefixState, hasPrefixState, nonce)
+				if !verifyNonce(hashed[:], target) {
+					continue
+				}
+				if atomic.CompareAndSwapInt32(&found, 0, 1) {
+					result <- strconv.Itoa(nonce)
+					cancel()
+				}
+				return
+			}
+		}(k)
+	}
+
+	go func() {
+		wg.Wait()
+		close(result)
+	}()
+
+	nonce, ok := <-result
+	if !ok {
+		return "", ctx.Err()
+	}
+	return nonce, nil
+}
+
+// marshalPrefixState hashes prefix once and returns the resulting internal
+// sha256 state, so each nonce attempt can resume from it instead of
+// re-hashing prefix from scratch. ok is false if the runtime's sha256
+// implementation doesn't support binary (un)marshaling, in which case
+// callers should fall back to hashing prefix+nonce directly.
+func marshalPrefixState(prefix string) (state []byte, ok bool) {
+	h := sha256.New()
+	h.Write([]byte(prefix))
+
+	marshaler, ok := h.(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, false
+	}
+	state, err := marshaler.MarshalBinary()
+	if err != nil {
+		return nil, false
+	}
+	return state, true
+}
+
+func hashChallenge(prefix string, prefixState []byte, hasPrefixState bool, nonce int) [sha256.Size]byte {
+	if hasPrefixState {
+		h := sha256.New()
+		if unmarshaler, ok := h.(encoding.BinaryUnmarshaler); ok {
+			if err := unmarshaler.UnmarshalBinary(prefixState); err == nil {
+				h.Write([]byte(strconv.Itoa(nonce)))
+				var out [sha256.Size]byte
+				copy(out[:], h.Sum(nil))
+				return out
+			}
+		}
+	}
+	return sha256.Sum256([]byte(prefix + strconv.Itoa(nonce)))
+}
+
+func verifyNonce(result []byte, target []byte) bool {
+	if len(result) != len(target) {
+		return false
+	}
+
+	for i := 0; i < len(result); i++ {
+		if result[i] > target[i] {
+			return false
+		} else if result[i] < target[i] {
+			break
+		}
+	}
+
+	return true
+}
+
+// Difficulty estimates the expected number of nonce attempts needed to
+// solve a challenge with the given target, i.e. roughly 2^(8*len(target)) /
+// target as an integer.
+func Difficulty(targetHex string) (float64, error) {
+	target, err := hex.DecodeString(targetHex)
+	if err != nil {
+		return 0, err
+	}
+	if len(target) == 0 {
+		return 0, errors.New("lrclib: empty challenge target")
+	}
+
+	targetInt := new(big.Int).SetBytes(target)
+	if targetInt.Sign() == 0 {
+		return math.Inf(1), nil
+	}
+
+	space := new(big.Int).Lsh(big.NewInt(1), uint(len(target)*8))
+	ratio := new(big.Float).Quo(new(big.Float).SetInt(space), new(big.Float).SetInt(targetInt))
+	estimate, _ := ratio.Float64()
+	return estimate, nil
+}
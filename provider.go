@@ -0,0 +1,168 @@
+package lrclib
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// TrackQuery identifies the track a Provider should look lyrics up for.
+// FilePath is optional and lets FilesystemProvider resolve a sidecar file
+// sitting next to the audio file itself.
+type TrackQuery struct {
+	TrackName  string
+	ArtistName string
+	AlbumName  string
+	Duration   time.Duration
+	FilePath   string
+}
+
+// Provider resolves lyrics for a track from some source, remote or local.
+type Provider interface {
+	Lookup(ctx context.Context, query TrackQuery) (SongData, error)
+}
+
+// HTTPProvider is a Provider backed by lrclib.net (or a compatible API).
+type HTTPProvider struct {
+	Client *Client
+}
+
+// NewHTTPProvider returns an HTTPProvider using client, or the package's
+// default client if client is nil.
+func NewHTTPProvider(client *Client) *HTTPProvider {
+	if client == nil {
+		client = defaultClient
+	}
+	return &HTTPProvider{Client: client}
+}
+
+func (p *HTTPProvider) Lookup(ctx context.Context, query TrackQuery) (SongData, error) {
+	return p.Client.GetSong(ctx, query.TrackName, query.ArtistName, query.AlbumName, query.Duration, false)
+}
+
+// FilesystemProvider resolves lyrics from .lrc/.txt sidecar files, either
+// next to the audio file itself or as "{Artist} - {Title}.lrc" under Dir.
+type FilesystemProvider struct {
+	// Dir is searched for "{Artist} - {Title}.lrc" (then ".txt") files when a
+	// query has no FilePath, or as a fallback when the sidecar next to
+	// FilePath is missing.
+	Dir string
+}
+
+// NewFilesystemProvider returns a FilesystemProvider that falls back to
+// "{Artist} - {Title}.lrc" files under dir.
+func NewFilesystemProvider(dir string) *FilesystemProvider {
+	return &FilesystemProvider{Dir: dir}
+}
+
+func (p *FilesystemProvider) Lookup(ctx context.Context, query TrackQuery) (SongData, error) {
+	if query.FilePath != "" {
+		base := strings.TrimSuffix(query.FilePath, filepath.Ext(query.FilePath))
+		for _, ext := range []string{".lrc", ".txt"} {
+			if song, ok := readSidecar(base+ext, query); ok {
+				return song, nil
+			}
+		}
+	}
+
+	if p.Dir != "" {
+		base := filepath.Join(p.Dir, fmt.Sprintf("%s - %s", query.ArtistName, query.TrackName))
+		for _, ext := range []string{".lrc", ".txt"} {
+			if song, ok := readSidecar(base+ext, query); ok {
+				return song, nil
+			}
+		}
+	}
+
+	return SongData{}, ErrNotFound
+}
+
+func readSidecar(path string, query TrackQuery) (SongData, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SongData{}, false
+	}
+
+	song := SongData{
+		TrackName:  query.TrackName,
+		ArtistName: query.ArtistName,
+		AlbumName:  query.AlbumName,
+		Duration:   query.Duration.Seconds(),
+	}
+	if strings.EqualFold(filepath.Ext(path), ".txt") {
+		song.PlainLyrics = string(data)
+	} else {
+		song.SyncedLyrics = string(data)
+	}
+	return song, true
+}
+
+// WriteSidecar writes song's lyrics next to query.FilePath, or as
+// "{Artist} - {Title}" under Dir if query has no FilePath. Synced lyrics
+// are written to a .lrc file and preferred over plain lyrics when both are
+// present; otherwise plain lyrics are written to a .txt file.
+func (p *FilesystemProvider) WriteSidecar(query TrackQuery, song SongData) error {
+	var base string
+	if query.FilePath != "" {
+		base = strings.TrimSuffix(query.FilePath, filepath.Ext(query.FilePath))
+	} else {
+		base = filepath.Join(p.Dir, fmt.Sprintf("%s - %s", query.ArtistName, query.TrackName))
+	}
+
+	if song.SyncedLyrics != "" {
+		return os.WriteFile(base+".lrc", []byte(song.SyncedLyrics), 0o644)
+	}
+	return os.WriteFile(base+".txt", []byte(song.PlainLyrics), 0o644)
+}
+
+// sidecarWriter is implemented by providers that Chain can write a
+// successful remote hit back to, so future lookups resolve offline.
+type sidecarWriter interface {
+	WriteSidecar(query TrackQuery, song SongData) error
+}
+
+type chain struct {
+	providers []Provider
+}
+
+// Chain tries providers in order, returning the first successful lookup. If
+// that lookup didn't come from the first provider in the chain and a
+// preceding provider can cache lyrics to disk (currently FilesystemProvider),
+// the result is written back so subsequent lookups are served locally.
+func Chain(providers ...Provider) Provider {
+	return &chain{providers: providers}
+}
+
+func (c *chain) Lookup(ctx context.Context, query TrackQuery) (SongData, error) {
+	var firstErr error
+	for i, p := range c.providers {
+		song, err := p.Lookup(ctx, query)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if i > 0 {
+			writeBack(c.providers[:i], query, song)
+		}
+		return song, nil
+	}
+	if firstErr == nil {
+		firstErr = ErrNotFound
+	}
+	return SongData{}, firstErr
+}
+
+// writeBack stores song with the first of providers that can write sidecars.
+func writeBack(providers []Provider, query TrackQuery, song SongData) {
+	for _, p := range providers {
+		if w, ok := p.(sidecarWriter); ok {
+			_ = w.WriteSidecar(query, song)
+			return
+		}
+	}
+}
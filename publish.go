@@ -0,0 +1,165 @@
+package lrclib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxPlausibleDuration caps the duration sanity check in validateSongData;
+// it's generous enough to cover long-form content like DJ sets, not just
+// typical songs.
+const maxPlausibleDuration = 6 * time.Hour
+
+// PublishOptions configures Client.PublishSongs.
+type PublishOptions struct {
+	// Concurrency bounds how many items are validated, deduped, and
+	// published at once. Defaults to runtime.NumCPU() if unset.
+	Concurrency int
+	// DryRun runs validation and dedup checks without calling /api/publish,
+	// so bulk-import tools can preview what would happen.
+	DryRun bool
+}
+
+// PublishStatus is the outcome of publishing a single item via PublishSongs.
+type PublishStatus int
+
+const (
+	StatusPublished PublishStatus = iota
+	StatusSkipped
+	StatusFailed
+)
+
+func (s PublishStatus) String() string {
+	switch s {
+	case StatusPublished:
+		return "published"
+	case StatusSkipped:
+		return "skipped"
+	case StatusFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// PublishResult is the per-item outcome of a Client.PublishSongs call. When
+// Status is StatusFailed and the failure came from the API itself (rather
+// than validation or a transport error), Err unwraps to a *PublishError via
+// errors.As.
+type PublishResult struct {
+	Song   SongData
+	Status PublishStatus
+	Err    error
+}
+
+// PublishSongs validates, deduplicates, and publishes songs concurrently,
+// solving one proof-of-work challenge per item. Items already present on
+// lrclib.net (matched by ID, or by track/artist/album/duration) are
+// skipped rather than republished. In opts.DryRun, matching items are still
+// reported as StatusSkipped but nothing is ever sent to /api/publish.
+func (c *Client) PublishSongs(ctx context.Context, songs []SongData, opts PublishOptions) []PublishResult {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+	if concurrency > len(songs) {
+		concurrency = len(songs)
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]PublishResult, len(songs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, song := range songs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, song SongData) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = c.publishOne(ctx, song, opts)
+		}(i, song)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func (c *Client) publishOne(ctx context.Context, song SongData, opts PublishOptions) PublishResult {
+	if err := validateSongData(song); err != nil {
+		return PublishResult{Song: song, Status: StatusFailed, Err: err}
+	}
+
+	duplicate, err := c.isDuplicate(ctx, song)
+	if err != nil {
+		return PublishResult{Song: song, Status: StatusFailed, Err: err}
+	}
+	if duplicate {
+		return PublishResult{Song: song, Status: StatusSkipped}
+	}
+
+	if opts.DryRun {
+		return PublishResult{Song: song, Status: StatusPublished}
+	}
+
+	if err := c.PublishSong(ctx, song); err != nil {
+		return PublishResult{Song: song, Status: StatusFailed, Err: err}
+	}
+	return PublishResult{Song: song, Status: StatusPublished}
+}
+
+// isDuplicate reports whether song already exists on lrclib.net, preferring
+// an ID lookup when song.ID is set.
+func (c *Client) isDuplicate(ctx context.Context, song SongData) (bool, error) {
+	var err error
+	if song.ID != 0 {
+		_, err = c.GetSongByID(ctx, strconv.Itoa(song.ID))
+	} else {
+		duration := time.Duration(song.Duration * float64(time.Second))
+		_, err = c.GetSong(ctx, song.TrackName, song.ArtistName, song.AlbumName, duration, false)
+	}
+
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	return false, err
+}
+
+// validateSongData checks that song is plausible enough to submit, without
+// making any network calls.
+func validateSongData(song SongData) error {
+	if strings.TrimSpace(song.TrackName) == "" {
+		return errors.New("lrclib: track name is required")
+	}
+	if strings.TrimSpace(song.ArtistName) == "" {
+		return errors.New("lrclib: artist name is required")
+	}
+	if song.Duration <= 0 || song.Duration > maxPlausibleDuration.Seconds() {
+		return fmt.Errorf("lrclib: implausible duration %.0fs", song.Duration)
+	}
+
+	hasLyrics := song.PlainLyrics != "" || song.SyncedLyrics != ""
+	if song.Instrumental && hasLyrics {
+		return errors.New("lrclib: instrumental tracks must not include lyrics")
+	}
+	if !song.Instrumental && !hasLyrics {
+		return errors.New("lrclib: non-instrumental tracks require plain or synced lyrics")
+	}
+
+	if song.SyncedLyrics != "" && len(ParseSyncedLyrics(song.SyncedLyrics)) == 0 {
+		return errors.New("lrclib: synced lyrics did not contain any parseable lines")
+	}
+
+	return nil
+}